@@ -0,0 +1,172 @@
+package cryptoballot
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+)
+
+// Algorithm identifies the signature algorithm used to sign a ballot or a
+// SignatureRequest, using the same short tokens JOSE/JWS uses for its `alg`
+// header (RS512, PS512, ES256, ES512). This lets the authority and the voter
+// negotiate a signature scheme instead of silently assuming PKCS1v15-SHA512.
+type Algorithm string
+
+const (
+	// AlgorithmRS512 is RSASSA-PKCS1-v1_5 using SHA-512. This is the
+	// original, and still default, algorithm for cryptoballot.
+	AlgorithmRS512 Algorithm = "RS512"
+
+	// AlgorithmPS512 is RSASSA-PSS using SHA-512 and PSSSaltLengthAuto.
+	AlgorithmPS512 Algorithm = "PS512"
+
+	// AlgorithmES256 is ECDSA using the P-256 curve and SHA-256.
+	AlgorithmES256 Algorithm = "ES256"
+
+	// AlgorithmES512 is ECDSA using the P-521 curve and SHA-512.
+	AlgorithmES512 Algorithm = "ES512"
+)
+
+// DefaultAlgorithm is used whenever a SignatureRequest does not explicitly
+// specify an algorithm, preserving backwards compatibility with the original
+// wire format.
+const DefaultAlgorithm = AlgorithmRS512
+
+// ErrUnsupportedAlgorithm is returned whenever a requested algorithm is not
+// implemented, or doesn't match the type of key it's being used with.
+var ErrUnsupportedAlgorithm = errors.New("cryptoballot: unsupported or mismatched signature algorithm")
+
+// Valid returns true if alg is one of the algorithms cryptoballot knows how
+// to sign and verify.
+func (alg Algorithm) Valid() bool {
+	switch alg {
+	case AlgorithmRS512, AlgorithmPS512, AlgorithmES256, AlgorithmES512:
+		return true
+	default:
+		return false
+	}
+}
+
+// Hash returns the crypto.Hash used by alg.
+func (alg Algorithm) Hash() crypto.Hash {
+	switch alg {
+	case AlgorithmRS512, AlgorithmPS512, AlgorithmES512:
+		return crypto.SHA512
+	case AlgorithmES256:
+		return crypto.SHA256
+	default:
+		return 0
+	}
+}
+
+// AlgorithmFor chooses the default Algorithm for a given private key, so
+// that callers don't have to hard-code a token for the common case. RSA keys
+// default to RS512 (for backwards compatibility); ECDSA keys default to
+// ES256 or ES512 depending on curve size.
+func AlgorithmFor(key crypto.Signer) (Algorithm, error) {
+	switch k := key.Public().(type) {
+	case *rsa.PublicKey:
+		return AlgorithmRS512, nil
+	case *ecdsa.PublicKey:
+		if k.Curve.Params().BitSize <= 256 {
+			return AlgorithmES256, nil
+		}
+		return AlgorithmES512, nil
+	default:
+		return "", ErrUnsupportedAlgorithm
+	}
+}
+
+// cryptoPublicKey decodes the base64-encoded, PEM-formatted public-key that
+// a PublicKey wraps into a standard library crypto.PublicKey, so that it can
+// be used with algorithms (RSA-PSS, ECDSA) the original PKCS1v15-only code
+// path never needed to reach into.
+func cryptoPublicKey(pub PublicKey) (crypto.PublicKey, error) {
+	der, err := derSubjectPublicKeyInfo(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, errors.New("cryptoballot: could not parse public-key: " + err.Error())
+	}
+
+	return key, nil
+}
+
+// signWithAlgorithm hashes message under alg and signs it with key, which
+// must be an *rsa.PrivateKey for RS512/PS512 or an *ecdsa.PrivateKey for
+// ES256/ES512.
+func signWithAlgorithm(alg Algorithm, key crypto.Signer, message []byte) ([]byte, error) {
+	if !alg.Valid() {
+		return nil, ErrUnsupportedAlgorithm
+	}
+
+	hash := alg.Hash().New()
+	hash.Write(message)
+	digest := hash.Sum(nil)
+
+	switch alg {
+	case AlgorithmRS512:
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, ErrUnsupportedAlgorithm
+		}
+		return rsa.SignPKCS1v15(rand.Reader, rsaKey, alg.Hash(), digest)
+	case AlgorithmPS512:
+		rsaKey, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, ErrUnsupportedAlgorithm
+		}
+		return rsa.SignPSS(rand.Reader, rsaKey, alg.Hash(), digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+	case AlgorithmES256, AlgorithmES512:
+		if _, ok := key.(*ecdsa.PrivateKey); !ok {
+			return nil, ErrUnsupportedAlgorithm
+		}
+		return key.Sign(rand.Reader, digest, alg.Hash())
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+// verifySignatureWithAlgorithm hashes message under alg and verifies
+// rawSignature against pub. It's the PS512/ES256/ES512 counterpart to
+// Signature.VerifySignature, which only ever understood RS512.
+func verifySignatureWithAlgorithm(alg Algorithm, pub PublicKey, message, rawSignature []byte) error {
+	if !alg.Valid() {
+		return ErrUnsupportedAlgorithm
+	}
+
+	key, err := cryptoPublicKey(pub)
+	if err != nil {
+		return err
+	}
+
+	hash := alg.Hash().New()
+	hash.Write(message)
+	digest := hash.Sum(nil)
+
+	switch alg {
+	case AlgorithmPS512:
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlgorithm
+		}
+		return rsa.VerifyPSS(rsaKey, alg.Hash(), digest, rawSignature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto})
+	case AlgorithmES256, AlgorithmES512:
+		ecdsaKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return ErrUnsupportedAlgorithm
+		}
+		if !ecdsa.VerifyASN1(ecdsaKey, digest, rawSignature) {
+			return errors.New("cryptoballot: ECDSA signature verification failed")
+		}
+		return nil
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+}
@@ -0,0 +1,79 @@
+package cryptoballot
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+)
+
+// newTestECDSAPublicKey wraps pub in a cryptoballot.PublicKey the same way
+// newTestPublicKey does for RSA keys: DER -> PEM -> base64.
+func newTestECDSAPublicKey(tb testing.TB, pub *ecdsa.PublicKey) PublicKey {
+	tb.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		tb.Fatalf("MarshalPKIXPublicKey: %s", err)
+	}
+
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	b64 := base64.StdEncoding.EncodeToString(pemBytes)
+
+	publicKey, err := NewPublicKey([]byte(b64))
+	if err != nil {
+		tb.Fatalf("NewPublicKey: %s", err)
+	}
+	return publicKey
+}
+
+func TestAlgorithmSignVerifyRoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey (RSA): %s", err)
+	}
+	rsaPub := newTestPublicKey(t, &rsaKey.PublicKey)
+
+	ecdsaKey256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey (P-256): %s", err)
+	}
+	ecdsaKey521, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey (P-521): %s", err)
+	}
+
+	tests := []struct {
+		algorithm Algorithm
+		key       crypto.Signer
+		pub       PublicKey
+	}{
+		{AlgorithmPS512, rsaKey, rsaPub},
+		{AlgorithmES256, ecdsaKey256, newTestECDSAPublicKey(t, &ecdsaKey256.PublicKey)},
+		{AlgorithmES512, ecdsaKey521, newTestECDSAPublicKey(t, &ecdsaKey521.PublicKey)},
+	}
+
+	message := []byte("envelope message under test")
+
+	for _, tc := range tests {
+		t.Run(string(tc.algorithm), func(t *testing.T) {
+			rawSignature, err := signWithAlgorithm(tc.algorithm, tc.key, message)
+			if err != nil {
+				t.Fatalf("signWithAlgorithm: %s", err)
+			}
+
+			if err := verifySignatureWithAlgorithm(tc.algorithm, tc.pub, message, rawSignature); err != nil {
+				t.Fatalf("verifySignatureWithAlgorithm: %s", err)
+			}
+
+			if err := verifySignatureWithAlgorithm(tc.algorithm, tc.pub, []byte("a different message"), rawSignature); err == nil {
+				t.Fatal("verifySignatureWithAlgorithm should fail against a different message")
+			}
+		})
+	}
+}
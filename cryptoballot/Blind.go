@@ -0,0 +1,134 @@
+package cryptoballot
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"math/big"
+)
+
+// ErrNotBlindable is returned whenever blinding or unblinding is attempted
+// against a public-key that isn't an RSA key. Textbook RSA blinding has no
+// ECDSA equivalent.
+var ErrNotBlindable = errors.New("cryptoballot: blind signatures require an RSA public-key")
+
+// Blind implements Chaum-style RSA blinding for a ballot. It hashes ballot
+// with SHA-512, picks a random blinding factor r coprime to pubKey's
+// modulus, and returns m' = H(ballot) * r^e mod N along with the unblinder
+// (r^-1 mod N) needed to later strip the blinding factor from a signature
+// over m'.
+//
+// The authority that signs the returned blinded bytes never sees H(ballot)
+// -- only m' -- so it learns nothing about which ballot it signed.
+func Blind(pubKey PublicKey, ballot []byte) (blinded []byte, unblinder *big.Int, err error) {
+	rsaKey, err := rsaPublicKey(pubKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hash := sha512.Sum512(ballot)
+	m := new(big.Int).SetBytes(hash[:])
+
+	n := rsaKey.N
+	e := big.NewInt(int64(rsaKey.E))
+
+	var r, rInv *big.Int
+	for {
+		r, err = rand.Int(rand.Reader, n)
+		if err != nil {
+			return nil, nil, err
+		}
+		if r.Sign() == 0 {
+			continue
+		}
+
+		rInv = new(big.Int).ModInverse(r, n)
+		if rInv != nil {
+			break
+		}
+		// gcd(r, N) != 1, retry with a fresh r.
+	}
+
+	rE := new(big.Int).Exp(r, e, n)
+	mBlinded := new(big.Int).Mod(new(big.Int).Mul(m, rE), n)
+
+	return mBlinded.Bytes(), rInv, nil
+}
+
+// Unblind strips the blinding factor from sig (a raw, unpadded RSA
+// signature over a blinded message produced by Blind) using unblinder (the
+// r^-1 returned alongside the blinded message), recovering the signature
+// over the original, unblinded message: s = s' * r^-1 mod N.
+func Unblind(sig Signature, unblinder *big.Int, pubKey PublicKey) (Signature, error) {
+	rsaKey, err := rsaPublicKey(pubKey)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	sPrime := new(big.Int).SetBytes(sig.Bytes())
+	s := new(big.Int).Mod(new(big.Int).Mul(sPrime, unblinder), rsaKey.N)
+
+	return NewSignatureFromBytes(s.Bytes())
+}
+
+// VerifyBlindSignature checks that sig is a valid (already unblinded)
+// textbook RSA signature over ballot: s^e mod N == H(ballot) mod N. Unlike
+// Signature.VerifySignature, this performs no PKCS1v15/PSS padding check,
+// since blind signatures are computed over the raw digest.
+func VerifyBlindSignature(sig Signature, pubKey PublicKey, ballot []byte) error {
+	rsaKey, err := rsaPublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+
+	hash := sha512.Sum512(ballot)
+	m := new(big.Int).SetBytes(hash[:])
+
+	s := new(big.Int).SetBytes(sig.Bytes())
+	e := big.NewInt(int64(rsaKey.E))
+	recovered := new(big.Int).Exp(s, e, rsaKey.N)
+
+	if recovered.Cmp(m) != 0 {
+		return errors.New("cryptoballot: blind signature verification failed")
+	}
+	return nil
+}
+
+// rsaPublicKey extracts the underlying *rsa.PublicKey from a PublicKey, for
+// the raw modular arithmetic that blind signatures require.
+func rsaPublicKey(pub PublicKey) (*rsa.PublicKey, error) {
+	key, err := cryptoPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, ErrNotBlindable
+	}
+	return rsaKey, nil
+}
+
+// SignBlindedBallot signs sigReq.Ballot as a raw, unpadded RSA signature --
+// s' = (m')^d mod N -- where m' is the already-blinded ballot produced by
+// Blind. Unlike SignBallot, no hash is computed here: the blinded bytes are
+// signed directly, so the authority never sees a hash of the unblinded
+// ballot.
+//
+// Use this only when sigReq.Ballot holds a Blind()-ed ballot; for ordinary,
+// unblinded ballots use SignBallot instead.
+func (sigReq *SignatureRequest) SignBlindedBallot(key *rsa.PrivateKey) (Signature, error) {
+	rawBytes := make([]byte, base64.StdEncoding.DecodedLen(len(sigReq.Ballot)))
+	n, err := base64.StdEncoding.Decode(rawBytes, sigReq.Ballot)
+	if err != nil {
+		return Signature{}, err
+	}
+	rawBytes = rawBytes[:n]
+
+	mPrime := new(big.Int).SetBytes(rawBytes)
+	sPrime := new(big.Int).Exp(mPrime, key.D, key.N)
+
+	return NewSignatureFromBytes(sPrime.Bytes())
+}
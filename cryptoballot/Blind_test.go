@@ -0,0 +1,108 @@
+package cryptoballot
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+)
+
+// newTestPublicKey wraps pub in a cryptoballot.PublicKey the same way a
+// voter or authority would advertise theirs: DER -> PEM -> base64.
+func newTestPublicKey(tb testing.TB, pub *rsa.PublicKey) PublicKey {
+	tb.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		tb.Fatalf("MarshalPKIXPublicKey: %s", err)
+	}
+
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	b64 := base64.StdEncoding.EncodeToString(pemBytes)
+
+	publicKey, err := NewPublicKey([]byte(b64))
+	if err != nil {
+		tb.Fatalf("NewPublicKey: %s", err)
+	}
+	return publicKey
+}
+
+func TestBlindUnblindRoundTrip(t *testing.T) {
+	authorityKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	authorityPub := newTestPublicKey(t, &authorityKey.PublicKey)
+
+	ballot := []byte("yes on proposition 9")
+
+	blinded, unblinder, err := Blind(authorityPub, ballot)
+	if err != nil {
+		t.Fatalf("Blind: %s", err)
+	}
+
+	sigReq := &SignatureRequest{
+		Ballot: []byte(base64.StdEncoding.EncodeToString(blinded)),
+	}
+	blindSig, err := sigReq.SignBlindedBallot(authorityKey)
+	if err != nil {
+		t.Fatalf("SignBlindedBallot: %s", err)
+	}
+
+	unblindedSig, err := Unblind(blindSig, unblinder, authorityPub)
+	if err != nil {
+		t.Fatalf("Unblind: %s", err)
+	}
+
+	if err := VerifyBlindSignature(unblindedSig, authorityPub, ballot); err != nil {
+		t.Fatalf("VerifyBlindSignature: %s", err)
+	}
+
+	if err := VerifyBlindSignature(unblindedSig, authorityPub, []byte("no on proposition 9")); err == nil {
+		t.Fatal("VerifyBlindSignature should fail against a different ballot")
+	}
+}
+
+// TestUnblindRequiresAuthorityKey reproduces the bug reported in review: the
+// public-key passed to Unblind must be the authority's (the key the
+// signature was produced under), not some unrelated voter identity key. The
+// modulus determines the blinding factor's modular inverse, so using the
+// wrong key yields a signature that silently fails verification rather than
+// erroring outright.
+func TestUnblindRequiresAuthorityKey(t *testing.T) {
+	authorityKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	authorityPub := newTestPublicKey(t, &authorityKey.PublicKey)
+
+	voterKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	voterPub := newTestPublicKey(t, &voterKey.PublicKey)
+
+	ballot := []byte("yes on proposition 9")
+
+	blinded, unblinder, err := Blind(authorityPub, ballot)
+	if err != nil {
+		t.Fatalf("Blind: %s", err)
+	}
+
+	sigReq := &SignatureRequest{
+		Ballot: []byte(base64.StdEncoding.EncodeToString(blinded)),
+	}
+	blindSig, err := sigReq.SignBlindedBallot(authorityKey)
+	if err != nil {
+		t.Fatalf("SignBlindedBallot: %s", err)
+	}
+
+	wrongUnblinded, err := Unblind(blindSig, unblinder, voterPub)
+	if err == nil {
+		if verr := VerifyBlindSignature(wrongUnblinded, authorityPub, ballot); verr == nil {
+			t.Fatal("unblinding with the voter's key instead of the authority's should not produce a signature that verifies")
+		}
+	}
+}
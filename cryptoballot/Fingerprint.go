@@ -0,0 +1,84 @@
+package cryptoballot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+)
+
+// fingerprintLength is the length, in bytes, of the truncated SHA-256 used
+// by Fingerprint (240 bits).
+const fingerprintLength = 30
+
+// derSubjectPublicKeyInfo decodes the base64-encoded, PEM-formatted
+// public-key that a PublicKey wraps and returns the raw DER-encoded
+// SubjectPublicKeyInfo bytes, without parsing them into a crypto.PublicKey.
+func derSubjectPublicKeyInfo(pub PublicKey) ([]byte, error) {
+	derPEM, err := base64.StdEncoding.DecodeString(pub.String())
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(derPEM)
+	if block == nil {
+		return nil, errors.New("cryptoballot: could not PEM-decode public-key")
+	}
+
+	return block.Bytes, nil
+}
+
+// GetSHA256DER returns the SHA-256 digest of the DER-encoded
+// SubjectPublicKeyInfo for pub, the libtrust/JOSE convention for deriving a
+// key identifier (as opposed to GetSHA512, which hashes the base64
+// PEM text).
+func (pub PublicKey) GetSHA256DER() []byte {
+	der, err := derSubjectPublicKeyInfo(pub)
+	if err != nil {
+		return nil
+	}
+	sum := sha256.Sum256(der)
+	return sum[:]
+}
+
+// Fingerprint returns a libtrust/JOSE-style key fingerprint: the SHA-256 of
+// the DER-encoded SubjectPublicKeyInfo, truncated to 240 bits, base32
+// encoded, and grouped into twelve `:`-separated 4-character groups (e.g.
+// "PYYO:TEWU:V7JH:..."). This lets a PublicKey be identified by systems
+// that issue JWT-based credentials, which know nothing of cryptoballot's
+// legacy hex(SHA512) RequestID.
+func (pub PublicKey) Fingerprint() string {
+	sum := pub.GetSHA256DER()
+	if sum == nil {
+		return ""
+	}
+
+	encoded := base32.StdEncoding.EncodeToString(sum[:fingerprintLength])
+
+	var groups []string
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+	return strings.Join(groups, ":")
+}
+
+// validateRequestID checks that requestID identifies pub, accepting either
+// the legacy hex(SHA512(base64 PEM)) RequestID or the newer libtrust-style
+// Fingerprint, distinguishing the two by shape (the fingerprint contains
+// `:` separators; the legacy ID does not).
+func validateRequestID(requestID []byte, pub PublicKey) error {
+	if bytes.ContainsRune(requestID, ':') {
+		if string(requestID) != pub.Fingerprint() {
+			return errors.New("Invalid Request ID. The fingerprint provided does not match the voters public key.")
+		}
+		return nil
+	}
+
+	if !bytes.Equal(requestID, pub.GetSHA512()) {
+		return errors.New("Invalid Request ID. A Request ID must be the (hex encoded) SHA512 of the voters public key.")
+	}
+	return nil
+}
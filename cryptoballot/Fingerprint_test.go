@@ -0,0 +1,75 @@
+package cryptoballot
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+)
+
+func TestFingerprintFormat(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	pub := newTestPublicKey(t, &key.PublicKey)
+
+	fingerprint := pub.Fingerprint()
+	if fingerprint == "" {
+		t.Fatal("Fingerprint returned empty string")
+	}
+
+	groups := strings.Split(fingerprint, ":")
+	if len(groups) != 12 {
+		t.Fatalf("expected 12 `:`-separated groups, got %d (%s)", len(groups), fingerprint)
+	}
+	for _, group := range groups {
+		if len(group) != 4 {
+			t.Fatalf("expected each group to be 4 characters, got %q in %s", group, fingerprint)
+		}
+	}
+
+	if got := pub.Fingerprint(); got != fingerprint {
+		t.Fatalf("Fingerprint is not deterministic: got %q, then %q", fingerprint, got)
+	}
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	otherPub := newTestPublicKey(t, &otherKey.PublicKey)
+	if otherPub.Fingerprint() == fingerprint {
+		t.Fatal("distinct keys produced the same fingerprint")
+	}
+}
+
+func TestValidateRequestID(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	pub := newTestPublicKey(t, &key.PublicKey)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	otherPub := newTestPublicKey(t, &otherKey.PublicKey)
+
+	// Legacy hex(SHA512) RequestID.
+	if err := validateRequestID(pub.GetSHA512(), pub); err != nil {
+		t.Fatalf("validateRequestID (legacy): %s", err)
+	}
+	if err := validateRequestID(otherPub.GetSHA512(), pub); err == nil {
+		t.Fatal("validateRequestID (legacy) should reject a RequestID for a different key")
+	}
+
+	// Newer libtrust-style Fingerprint RequestID, distinguished by its `:`
+	// separators.
+	if err := validateRequestID([]byte(pub.Fingerprint()), pub); err != nil {
+		t.Fatalf("validateRequestID (fingerprint): %s", err)
+	}
+	if err := validateRequestID([]byte(otherPub.Fingerprint()), pub); err == nil {
+		t.Fatal("validateRequestID (fingerprint) should reject a RequestID for a different key")
+	}
+}
@@ -3,17 +3,15 @@ package cryptoballot
 import (
 	"bytes"
 	"crypto"
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/sha512"
 	"encoding/base64"
 	"errors"
 	"strings"
 )
 
 type SignatureRequest struct {
+	Algorithm  // Algorithm used to sign the ballot, eg "RS512". Empty for the true legacy 5-part wire format (implies DefaultAlgorithm, unbound from the signed message); explicit otherwise.
 	ElectionID string
-	RequestID  []byte // SHA512 (hex) of base64 encoded public-key
+	RequestID  []byte // SHA512 (hex) of base64 encoded public-key, or its PublicKey.Fingerprint()
 	PublicKey         // base64 encoded PEM formatted public-key
 	Ballot     []byte // base64 encoded ballot blob, it could be either blinded or unblinded.
 	Signature         // Voter signature for the ballot request
@@ -25,6 +23,7 @@ type SignatureRequest struct {
 func NewSignatureRequest(rawSignatureRequest []byte) (*SignatureRequest, error) {
 	var (
 		err        error
+		algorithm  Algorithm
 		electionID string
 		requestID  []byte
 		publicKey  PublicKey
@@ -32,9 +31,28 @@ func NewSignatureRequest(rawSignatureRequest []byte) (*SignatureRequest, error)
 		signature  Signature
 	)
 
+	// Sniff for the versioned JSON wire format before falling back to the
+	// legacy `\n\n`-delimited text format.
+	if trimmed := bytes.TrimSpace(rawSignatureRequest); len(trimmed) > 0 && trimmed[0] == '{' {
+		return NewSignatureRequestFromJSON(trimmed)
+	}
+
 	parts := bytes.Split(rawSignatureRequest, []byte("\n\n"))
 
-	if len(parts) != 5 {
+	// The algorithm line is optional, for backwards compatibility with the
+	// original 5-part format. When present it's a leading 6th part. Leave
+	// algorithm unset (rather than defaulting it) for the 5-part case: there
+	// was never an algorithm component in the message the voter signed, so
+	// none must be bound into it during verification either.
+	switch len(parts) {
+	case 5:
+	case 6:
+		algorithm = Algorithm(parts[0])
+		if !algorithm.Valid() {
+			return &SignatureRequest{}, errors.New("Unknown signature algorithm: " + string(parts[0]))
+		}
+		parts = parts[1:]
+	default:
 		return &SignatureRequest{}, errors.New("Cannot read Signature Request. Invalid format")
 	}
 
@@ -46,8 +64,8 @@ func NewSignatureRequest(rawSignatureRequest []byte) (*SignatureRequest, error)
 	}
 
 	requestID = parts[1]
-	if !bytes.Equal(requestID, publicKey.GetSHA512()) {
-		return &SignatureRequest{}, errors.New("Invalid Request ID. A Request ID must be the (hex encoded) SHA512 of the voters public key.")
+	if err = validateRequestID(requestID, publicKey); err != nil {
+		return &SignatureRequest{}, err
 	}
 
 	ballot = parts[3]
@@ -61,6 +79,7 @@ func NewSignatureRequest(rawSignatureRequest []byte) (*SignatureRequest, error)
 	}
 
 	sigReq := SignatureRequest{
+		algorithm,
 		electionID,
 		requestID,
 		publicKey,
@@ -77,19 +96,52 @@ func NewSignatureRequest(rawSignatureRequest []byte) (*SignatureRequest, error)
 	return &sigReq, nil
 }
 
+// envelopeMessage builds the byte string the voter's signature covers. When
+// algorithm is non-empty, it's bound into the message (JOSE-style) so that
+// the algorithm a request claims to use can't be swapped in transit without
+// invalidating the signature. When algorithm is empty -- the true legacy
+// 5-part wire format, which never carried an algorithm component -- it's
+// omitted entirely, since there's no `alg` field in that format to protect
+// against downgrade in the first place.
+func envelopeMessage(algorithm Algorithm, electionID string, requestID []byte, publicKey PublicKey, ballot []byte) []byte {
+	s := []string{
+		electionID,
+		string(requestID),
+		publicKey.String(),
+		string(ballot),
+	}
+	if algorithm != "" {
+		s = append([]string{string(algorithm)}, s...)
+	}
+	return []byte(strings.Join(s, "\n\n"))
+}
+
 // Verify the voter's signature attached to the SignatureRequest
 func (sigReq *SignatureRequest) VerifySignature() error {
-	s := []string{
-		sigReq.ElectionID,
-		string(sigReq.RequestID),
-		sigReq.PublicKey.String(),
-		string(sigReq.Ballot),
+	// sigReq.Algorithm is only bound into the signed message below when it
+	// was explicitly present on the wire (the 6-part text format or JSON);
+	// it's left as-is (possibly empty) for that purpose. For picking which
+	// verification routine to run, though, an empty Algorithm always means
+	// RS512, so default it for that decision only.
+	verifyAlgorithm := sigReq.Algorithm
+	if verifyAlgorithm == "" {
+		verifyAlgorithm = DefaultAlgorithm
+	}
+
+	message := envelopeMessage(sigReq.Algorithm, sigReq.ElectionID, sigReq.RequestID, sigReq.PublicKey, sigReq.Ballot)
+
+	// RS512 is the original algorithm and is verified by the Signature type
+	// itself, to preserve backwards compatibility.
+	if verifyAlgorithm == AlgorithmRS512 {
+		return sigReq.Signature.VerifySignature(sigReq.PublicKey, message)
 	}
 
-	return sigReq.Signature.VerifySignature(sigReq.PublicKey, []byte(strings.Join(s, "\n\n")))
+	return verifySignatureWithAlgorithm(verifyAlgorithm, sigReq.PublicKey, message, sigReq.Signature.Bytes())
 }
 
 // Implements Stringer. Outputs the same text representation we are expecting the voter to POST in their Signature Request.
+// A SignatureRequest built (or parsed) without an explicit Algorithm round-trips
+// through the true legacy 5-part format, with no algorithm line.
 func (sigReq *SignatureRequest) String() string {
 	s := []string{
 		sigReq.ElectionID,
@@ -98,21 +150,37 @@ func (sigReq *SignatureRequest) String() string {
 		string(sigReq.Ballot),
 		sigReq.Signature.String(),
 	}
+	if sigReq.Algorithm != "" {
+		s = append([]string{string(sigReq.Algorithm)}, s...)
+	}
+
 	return strings.Join(s, "\n\n")
 }
 
-// Sign the blinded ballot attached to the Signature Request. The ballot should be base64 encoded.
-func (sigReq *SignatureRequest) SignBallot(key *rsa.PrivateKey) (Signature, error) {
+// Sign the ballot attached to the Signature Request, hashing it and signing
+// the digest under sigReq.Algorithm (or DefaultAlgorithm, if unset). The
+// ballot should be base64 encoded.
+//
+// This is for ordinary (non-blind) ballots. If sigReq.Ballot holds a
+// Blind()-ed ballot, use SignBlindedBallot instead, which signs the raw
+// blinded bytes without hashing them.
+//
+// key may be an *rsa.PrivateKey or an *ecdsa.PrivateKey; it must match
+// sigReq.Algorithm or an error is returned.
+func (sigReq *SignatureRequest) SignBallot(key crypto.Signer) (Signature, error) {
+	algorithm := sigReq.Algorithm
+	if algorithm == "" {
+		algorithm = DefaultAlgorithm
+	}
+
 	rawBytes := make([]byte, base64.StdEncoding.DecodedLen(len(sigReq.Ballot)))
-	_, err := base64.StdEncoding.Decode(rawBytes, sigReq.Ballot)
+	n, err := base64.StdEncoding.Decode(rawBytes, sigReq.Ballot)
 	if err != nil {
 		return Signature{}, err
 	}
+	rawBytes = rawBytes[:n]
 
-	hash := sha512.New()
-	hash.Write(rawBytes)
-
-	rawSignature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, hash.Sum(nil))
+	rawSignature, err := signWithAlgorithm(algorithm, key, rawBytes)
 	if err != nil {
 		return Signature{}, err
 	}
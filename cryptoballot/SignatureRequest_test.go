@@ -0,0 +1,109 @@
+package cryptoballot
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"testing"
+)
+
+// signEnvelope signs envelopeMessage(algorithm, ...) with voterKey under
+// RS512 and returns the hex-encoded Signature text NewSignatureRequest
+// expects as the final `\n\n`-delimited part.
+func signEnvelope(tb testing.TB, voterKey *rsa.PrivateKey, algorithm Algorithm, electionID string, requestID []byte, publicKey PublicKey, ballot []byte) []byte {
+	tb.Helper()
+
+	message := envelopeMessage(algorithm, electionID, requestID, publicKey, ballot)
+	digest := sha512.Sum512(message)
+
+	rawSig, err := rsa.SignPKCS1v15(rand.Reader, voterKey, crypto.SHA512, digest[:])
+	if err != nil {
+		tb.Fatalf("SignPKCS1v15: %s", err)
+	}
+	signature, err := NewSignatureFromBytes(rawSig)
+	if err != nil {
+		tb.Fatalf("NewSignatureFromBytes: %s", err)
+	}
+	return []byte(signature.String())
+}
+
+// TestNewSignatureRequestLegacyFormat verifies that the true legacy 5-part
+// format -- with no algorithm line at all -- still round-trips: the message
+// it was originally signed over (ElectionID/RequestID/PublicKey/Ballot, with
+// no algorithm component) must still verify, and the resulting
+// SignatureRequest's Algorithm must come back empty rather than defaulted.
+func TestNewSignatureRequestLegacyFormat(t *testing.T) {
+	voterKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	voterPub := newTestPublicKey(t, &voterKey.PublicKey)
+
+	electionID := "election-1"
+	requestID := voterPub.GetSHA512()
+	ballot := []byte(base64.StdEncoding.EncodeToString([]byte("yes")))
+
+	signature := signEnvelope(t, voterKey, "", electionID, requestID, voterPub, ballot)
+
+	raw := bytes.Join([][]byte{
+		[]byte(electionID),
+		requestID,
+		[]byte(voterPub.String()),
+		ballot,
+		signature,
+	}, []byte("\n\n"))
+
+	sigReq, err := NewSignatureRequest(raw)
+	if err != nil {
+		t.Fatalf("NewSignatureRequest: %s", err)
+	}
+	if sigReq.Algorithm != "" {
+		t.Fatalf("expected empty Algorithm for legacy 5-part format, got %q", sigReq.Algorithm)
+	}
+}
+
+// TestNewSignatureRequestExplicitAlgorithm verifies that the 6-part text
+// format, which carries an explicit algorithm line, binds it into the
+// signed message -- and that the resulting SignatureRequest remembers the
+// explicit algorithm rather than leaving it empty.
+func TestNewSignatureRequestExplicitAlgorithm(t *testing.T) {
+	voterKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	voterPub := newTestPublicKey(t, &voterKey.PublicKey)
+
+	electionID := "election-1"
+	requestID := voterPub.GetSHA512()
+	ballot := []byte(base64.StdEncoding.EncodeToString([]byte("yes")))
+
+	signature := signEnvelope(t, voterKey, AlgorithmRS512, electionID, requestID, voterPub, ballot)
+
+	raw := bytes.Join([][]byte{
+		[]byte(AlgorithmRS512),
+		[]byte(electionID),
+		requestID,
+		[]byte(voterPub.String()),
+		ballot,
+		signature,
+	}, []byte("\n\n"))
+
+	sigReq, err := NewSignatureRequest(raw)
+	if err != nil {
+		t.Fatalf("NewSignatureRequest: %s", err)
+	}
+	if sigReq.Algorithm != AlgorithmRS512 {
+		t.Fatalf("expected Algorithm %q, got %q", AlgorithmRS512, sigReq.Algorithm)
+	}
+
+	// Swapping the algorithm line post-signing, without re-signing, must
+	// invalidate the signature: the algorithm is bound into the message for
+	// this (explicit) format, unlike the legacy 5-part one.
+	tampered := bytes.Replace(raw, []byte(AlgorithmRS512), []byte(AlgorithmPS512), 1)
+	if _, err := NewSignatureRequest(tampered); err == nil {
+		t.Fatal("expected swapping the algorithm line to invalidate the signature, got nil error")
+	}
+}
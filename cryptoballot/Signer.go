@@ -0,0 +1,103 @@
+package cryptoballot
+
+import (
+	"crypto/rsa"
+	"sync"
+)
+
+// SignResult pairs a SignatureRequest processed by SignStream with its
+// outcome, so a caller reading from the output channel can tell which
+// request a Signature (or error) belongs to.
+type SignResult struct {
+	Request   *SignatureRequest
+	Signature Signature
+	Err       error
+}
+
+// Signer signs many SignatureRequests concurrently with a single RSA
+// private-key, amortizing the key's CRT precomputation across a pool of
+// worker goroutines instead of re-entering SignBallot serially.
+type Signer struct {
+	key     *rsa.PrivateKey
+	workers int
+}
+
+// NewSigner precomputes key's CRT values once and returns a Signer that
+// dispatches SignBatch/SignStream work across workers goroutines. workers
+// is clamped to 1 if it's less than 1.
+func NewSigner(key *rsa.PrivateKey, workers int) *Signer {
+	key.Precompute()
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &Signer{
+		key:     key,
+		workers: workers,
+	}
+}
+
+// SignBatch verifies and signs reqs concurrently across the Signer's worker
+// pool, returning a Signature and error for each request at the
+// corresponding index. Voter signature verification (which is independent
+// per-request) and SignBallot are both parallelized.
+func (s *Signer) SignBatch(reqs []*SignatureRequest) ([]Signature, []error) {
+	signatures := make([]Signature, len(reqs))
+	errs := make([]error, len(reqs))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < s.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				signatures[i], errs[i] = s.sign(reqs[i])
+			}
+		}()
+	}
+
+	for i := range reqs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return signatures, errs
+}
+
+// SignStream reads SignatureRequests from in, verifies and signs each one
+// across the Signer's worker pool, and writes a SignResult for each to out.
+// SignStream closes out once in is closed and every in-flight request has
+// been processed.
+func (s *Signer) SignStream(in <-chan *SignatureRequest, out chan<- SignResult) {
+	var wg sync.WaitGroup
+
+	for w := 0; w < s.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range in {
+				signature, err := s.sign(req)
+				out <- SignResult{
+					Request:   req,
+					Signature: signature,
+					Err:       err,
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(out)
+}
+
+// sign verifies req's voter signature and, if valid, signs its ballot.
+func (s *Signer) sign(req *SignatureRequest) (Signature, error) {
+	if err := req.VerifySignature(); err != nil {
+		return Signature{}, err
+	}
+	return req.SignBallot(s.key)
+}
@@ -0,0 +1,103 @@
+package cryptoballot
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"testing"
+)
+
+// newTestSignatureRequest builds a SignatureRequest whose voter signature
+// actually verifies, so Signer.SignBatch/SignStream exercise the real
+// VerifySignature + SignBallot path rather than erroring out immediately.
+func newTestSignatureRequest(tb testing.TB, voterKey *rsa.PrivateKey, electionID string, ballot []byte) *SignatureRequest {
+	tb.Helper()
+
+	voterPub := newTestPublicKey(tb, &voterKey.PublicKey)
+	requestID := voterPub.GetSHA512()
+	ballotB64 := []byte(base64.StdEncoding.EncodeToString(ballot))
+
+	message := envelopeMessage("", electionID, requestID, voterPub, ballotB64)
+	digest := sha512.Sum512(message)
+
+	rawSig, err := rsa.SignPKCS1v15(rand.Reader, voterKey, crypto.SHA512, digest[:])
+	if err != nil {
+		tb.Fatalf("SignPKCS1v15: %s", err)
+	}
+	signature, err := NewSignatureFromBytes(rawSig)
+	if err != nil {
+		tb.Fatalf("NewSignatureFromBytes: %s", err)
+	}
+
+	return &SignatureRequest{
+		ElectionID: electionID,
+		RequestID:  requestID,
+		PublicKey:  voterPub,
+		Ballot:     ballotB64,
+		Signature:  signature,
+	}
+}
+
+func TestSignerSignBatch(t *testing.T) {
+	authorityKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+	voterKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %s", err)
+	}
+
+	reqs := make([]*SignatureRequest, 10)
+	for i := range reqs {
+		reqs[i] = newTestSignatureRequest(t, voterKey, "election-1", []byte(fmt.Sprintf("ballot-%d", i)))
+	}
+
+	signer := NewSigner(authorityKey, 4)
+	signatures, errs := signer.SignBatch(reqs)
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("SignBatch[%d]: %s", i, err)
+		}
+		if len(signatures[i].Bytes()) == 0 {
+			t.Fatalf("SignBatch[%d]: got empty signature", i)
+		}
+	}
+}
+
+func BenchmarkSignerSignBatch(b *testing.B) {
+	authorityKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		b.Fatalf("GenerateKey: %s", err)
+	}
+	voterKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		b.Fatalf("GenerateKey: %s", err)
+	}
+
+	const batchSize = 64
+	reqs := make([]*SignatureRequest, batchSize)
+	for i := range reqs {
+		reqs[i] = newTestSignatureRequest(b, voterKey, "election-1", []byte(fmt.Sprintf("ballot-%d", i)))
+	}
+
+	for _, workers := range []int{1, 2, 4, 8, 16} {
+		workers := workers
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			signer := NewSigner(authorityKey, workers)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, errs := signer.SignBatch(reqs)
+				for _, err := range errs {
+					if err != nil {
+						b.Fatalf("SignBatch: %s", err)
+					}
+				}
+			}
+		})
+	}
+}